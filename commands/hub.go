@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v3"
+	"github.com/zyrouge/pho/core"
+	"github.com/zyrouge/pho/core/hub"
+	"github.com/zyrouge/pho/utils"
+)
+
+var HubCommand = cli.Command{
+	Name:  "hub",
+	Usage: "Browse and sync the application hub",
+	Commands: []*cli.Command{
+		&HubUpdateCommand,
+		&HubSearchCommand,
+		&HubInfoCommand,
+	},
+}
+
+var HubUpdateCommand = cli.Command{
+	Name:  "update",
+	Usage: "Sync the hub index from the configured hub URLs",
+	Action: func(ctx *cli.Context) error {
+		config, err := core.GetConfig()
+		if err != nil {
+			return err
+		}
+		if len(config.HubUrls) == 0 {
+			return errors.New("no hub urls configured")
+		}
+		index, err := hub.Update(config.HubUrls)
+		if err != nil {
+			return err
+		}
+		utils.LogInfo(
+			fmt.Sprintf(
+				"%s Synced %s applications from %d hub(s)",
+				utils.LogTickPrefix,
+				color.CyanString(fmt.Sprint(len(index.Items))),
+				len(config.HubUrls),
+			),
+		)
+		return nil
+	},
+}
+
+var HubSearchCommand = cli.Command{
+	Name:  "search",
+	Usage: "Search the hub index",
+	Action: func(ctx *cli.Context) error {
+		args := ctx.Args()
+		if args.Len() != 1 {
+			return errors.New("expected exactly one search query")
+		}
+		index, err := hub.Load()
+		if err != nil {
+			return err
+		}
+		matches := index.Search(args.Get(0))
+		if len(matches) == 0 {
+			utils.LogWarning("no matching applications found")
+			return nil
+		}
+		table := utils.NewLogTable()
+		for _, item := range matches {
+			table.Add(utils.LogRightArrowPrefix, item.Id, item.Name)
+		}
+		table.Print()
+		return nil
+	},
+}
+
+var HubInfoCommand = cli.Command{
+	Name:  "info",
+	Usage: "Show details about a hub application",
+	Action: func(ctx *cli.Context) error {
+		args := ctx.Args()
+		if args.Len() != 1 {
+			return errors.New("expected exactly one application id")
+		}
+		id := args.Get(0)
+		index, err := hub.Load()
+		if err != nil {
+			return err
+		}
+		item, ok := index.Get(id)
+		if !ok {
+			return fmt.Errorf("no hub entry found for %q", id)
+		}
+		table := utils.NewLogTable()
+		table.Add(utils.LogRightArrowPrefix, "Id", color.CyanString(item.Id))
+		table.Add(utils.LogRightArrowPrefix, "Name", color.CyanString(item.Name))
+		table.Add(utils.LogRightArrowPrefix, "Source", color.CyanString(string(item.Source)))
+		table.Add(utils.LogRightArrowPrefix, "Repository", color.CyanString(fmt.Sprintf("%s/%s", item.Owner, item.Repo)))
+		if item.Description != "" {
+			table.Add(utils.LogRightArrowPrefix, "Description", item.Description)
+		}
+		table.Print()
+		return nil
+	},
+}
@@ -1,15 +1,23 @@
 package commands
 
 import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v3"
 	"github.com/zyrouge/pho/core"
+	"github.com/zyrouge/pho/core/downloader"
+	"github.com/zyrouge/pho/core/hub"
 	"github.com/zyrouge/pho/utils"
 )
 
@@ -21,6 +29,88 @@ var InstallCommand = cli.Command{
 		&InstallGithubCommand,
 		&InstallLocalCommand,
 		&InstallHttpCommand,
+		&InstallGitlabCommand,
+		&InstallGiteaCommand,
+		&InstallSourceforgeCommand,
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "name",
+			Usage: "Application name",
+		},
+		&cli.BoolFlag{
+			Name:    "assume-yes",
+			Aliases: []string{"y"},
+			Usage:   "Automatically answer yes for questions",
+		},
+		&cli.BoolFlag{
+			Name:  "no-verify",
+			Usage: "Skip checksum and signature verification",
+		},
+		&cli.BoolFlag{
+			Name:  "require-signature",
+			Usage: "Fail if no valid GPG signature is found for the asset",
+		},
+		&cli.IntFlag{
+			Name:  "parallel",
+			Usage: "Number of concurrent chunks to download the asset in",
+			Value: 1,
+		},
+		&cli.IntFlag{
+			Name:  "retries",
+			Usage: "Number of retries per chunk on transient download failures",
+			Value: 3,
+		},
+		&cli.BoolFlag{
+			Name:  "resume",
+			Usage: "Resume a previously interrupted download",
+		},
+	},
+	// Action only runs when no subcommand matches the first argument, which
+	// lets `pho install <id>` resolve an id straight from the hub instead
+	// of requiring `pho install github <url>`.
+	Action: func(ctx *cli.Context) error {
+		args := ctx.Args()
+		if args.Len() == 0 {
+			return errors.New("no application specified")
+		}
+		if args.Len() > 1 {
+			return errors.New("unexpected excessive arguments")
+		}
+
+		id := args.Get(0)
+		index, err := hub.Load()
+		if err != nil {
+			return err
+		}
+		item, ok := index.Get(id)
+		if !ok {
+			return fmt.Errorf("no hub entry found for %q, run `pho hub update` or use `pho install github <url>`", id)
+		}
+		if item.Disabled {
+			return fmt.Errorf("hub entry %q is disabled", id)
+		}
+		source, err := item.ToGithubSource()
+		if err != nil {
+			return err
+		}
+
+		config, err := core.GetConfig()
+		if err != nil {
+			return err
+		}
+		appName := ctx.String("name")
+		if appName == "" {
+			appName = item.Name
+		}
+		return installGithubSource(config, bufio.NewReader(os.Stdin), source, item.Id, appName, installOptions{
+			AssumeYes:        ctx.Bool("assume-yes"),
+			NoVerify:         ctx.Bool("no-verify"),
+			RequireSignature: ctx.Bool("require-signature"),
+			Parallel:         int(ctx.Int("parallel")),
+			Retries:          int(ctx.Int("retries")),
+			Resume:           ctx.Bool("resume"),
+		})
 	},
 }
 
@@ -51,18 +141,48 @@ type InstallableApp struct {
 	Progress   int64
 	PrintCycle int
 	Status     InstallableAppStatus
+
+	// NoVerify skips checksum and signature verification entirely.
+	NoVerify bool
+	// RequireSignature fails the install if no GPG signature could be verified.
+	RequireSignature bool
+	// Checksum, when set, is compared against the downloaded asset instead of
+	// any checksum asset discovered alongside it.
+	Checksum string
+
+	// Parallel is the number of concurrent range requests used to download
+	// the asset. Values <= 1 download it as a single stream.
+	Parallel int
+	// Retries is the number of additional attempts made per chunk before
+	// the download is considered failed.
+	Retries int
+	// Resume continues a previous, interrupted download instead of
+	// restarting it from scratch.
+	Resume bool
+
+	Verification core.VerificationResult
+
+	board *installStatusBoard
+	// backupAppImage is where Download moved an existing AppImage aside
+	// before writing the new one in its place, if any. It is removed once
+	// SaveConfig succeeds; a failure anywhere in between leaves it for
+	// rollback/recover to restore.
+	backupAppImage string
 }
 
 func (x *InstallableApp) Write(data []byte) (n int, err error) {
 	l := len(data)
-	x.Progress += int64(l)
+	x.addProgress(int64(l))
 	return l, nil
 }
 
+// addProgress is safe to call concurrently, since parallel download chunks
+// report progress from their own goroutines.
+func (x *InstallableApp) addProgress(n int64) {
+	atomic.AddInt64(&x.Progress, n)
+}
+
 func (x *InstallableApp) PrintStatus() {
-	if x.PrintCycle > 0 {
-		utils.TerminalErasePreviousLine()
-	}
 	x.PrintCycle++
 
 	prefix := color.HiBlackString(fmt.Sprintf("[%d/%d]", x.Index+1, x.Count))
@@ -70,10 +190,11 @@ func (x *InstallableApp) PrintStatus() {
 		fmt.Sprintf("(%s)", utils.HumanizeSeconds(utils.TimeNowSeconds()-x.StartedAt)),
 	)
 
+	var line string
 	switch x.Status {
 	case InstallableAppFailed:
-		fmt.Printf(
-			"%s %s %s %s\n",
+		line = fmt.Sprintf(
+			"%s %s %s %s",
 			prefix,
 			utils.LogExclamationPrefix,
 			x.App.Version,
@@ -81,19 +202,19 @@ func (x *InstallableApp) PrintStatus() {
 		)
 
 	case InstallableAppDownloading:
-		fmt.Printf(
-			"%s %s %s (%s / %s) %s\n",
+		line = fmt.Sprintf(
+			"%s %s %s (%s / %s) %s",
 			prefix,
 			color.YellowString(utils.TerminalLoadingSymbol(x.PrintCycle)),
 			x.App.Version,
-			prettyBytes(x.Progress),
+			prettyBytes(atomic.LoadInt64(&x.Progress)),
 			prettyBytes(x.Asset.Size),
 			suffix,
 		)
 
 	case InstallableAppIntegrating:
-		fmt.Printf(
-			"%s %s %s %s\n",
+		line = fmt.Sprintf(
+			"%s %s %s %s",
 			prefix,
 			color.YellowString(utils.TerminalLoadingSymbol(x.PrintCycle)),
 			x.App.Version,
@@ -101,14 +222,47 @@ func (x *InstallableApp) PrintStatus() {
 		)
 
 	case InstallableAppInstalled:
-		fmt.Printf(
-			"%s %s %s %s\n",
+		line = fmt.Sprintf(
+			"%s %s %s %s",
 			prefix,
 			utils.LogTickPrefix,
 			x.App.Version,
 			suffix,
 		)
 	}
+
+	if x.board != nil {
+		x.board.set(x.Index, line)
+		return
+	}
+	fmt.Println(line)
+}
+
+// installStatusBoard renders one status line per app, keyed by index, so
+// that several apps installing concurrently can each update their own line
+// without racing to erase/redraw a single shared "previous line". Every
+// update redraws the whole board in place.
+type installStatusBoard struct {
+	mu       sync.Mutex
+	lines    []string
+	rendered int
+}
+
+func newInstallStatusBoard(count int) *installStatusBoard {
+	return &installStatusBoard{lines: make([]string, count)}
+}
+
+func (b *installStatusBoard) set(index int, line string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lines[index] = line
+	for i := 0; i < b.rendered; i++ {
+		utils.TerminalErasePreviousLine()
+	}
+	for _, l := range b.lines {
+		fmt.Println(l)
+	}
+	b.rendered = len(b.lines)
 }
 
 const printStatusTickerDuration = time.Second / 4
@@ -123,39 +277,90 @@ func (x *InstallableApp) StartStatusTicker() *time.Ticker {
 	return ticker
 }
 
+// installWorkerCount caps how many apps are downloaded/integrated at once.
+// Installs are mostly I/O bound, so this is generous compared to a typical
+// CPU-bound worker pool size.
+const installWorkerCount = 4
+
+// InstallApps installs apps using a bounded worker pool, so that multiple
+// apps can download and integrate concurrently. Per-app status lines are
+// rendered through a shared board keyed by index, since erasing "the
+// previous line" no longer makes sense once several apps print at once.
 func InstallApps(apps []InstallableApp) (int, int) {
-	success := 0
 	count := len(apps)
-	for i := range apps {
-		x := &apps[i]
-		x.Index = i
-		x.Count = count
-		x.StartedAt = utils.TimeNowSeconds()
-		x.Status = InstallableAppDownloading
-		x.PrintStatus()
-		core.UpdateTransactions(func(transactions *core.Transactions) error {
-			transactions.PendingInstallations[x.App.Id] = core.PendingInstallation{
-				InvolvedDirs:  []string{x.App.Paths.Dir},
-				InvolvedFiles: []string{x.App.Paths.Desktop},
+	board := newInstallStatusBoard(count)
+
+	workers := installWorkerCount
+	if workers > count {
+		workers = count
+	}
+	jobs := make(chan int)
+	var success int32
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				x := &apps[i]
+				x.Index = i
+				x.Count = count
+				x.StartedAt = utils.TimeNowSeconds()
+				x.Status = InstallableAppDownloading
+				x.board = board
+				x.PrintStatus()
+
+				var previousApp *core.AppConfig
+				if config, err := core.GetConfig(); err == nil {
+					if _, ok := config.Installed[x.App.Id]; ok {
+						previousApp, _ = core.ReadAppConfig(config, x.App.Id)
+					}
+				}
+				core.UpdateTransactions(func(transactions *core.Transactions) error {
+					pending := core.PendingInstallation{
+						InvolvedFiles: []string{x.App.Paths.AppImage + downloadPartSuffix},
+						Phase:         core.PhaseDownloading,
+						PreviousApp:   previousApp,
+					}
+					if previousApp == nil {
+						// Nothing preexists at this app's dir/desktop entry
+						// yet, so a rollback may remove them entirely. On an
+						// upgrade (previousApp != nil) they must be left out
+						// here, since they still hold the working install.
+						pending.InvolvedDirs = []string{x.App.Paths.Dir}
+						pending.InvolvedFiles = append(pending.InvolvedFiles, x.App.Paths.Desktop)
+					}
+					transactions.PendingInstallations[x.App.Id] = pending
+					return nil
+				})
+
+				if err := x.Install(); err != nil {
+					x.Status = InstallableAppFailed
+					x.PrintStatus()
+					utils.LogError(err)
+					x.rollback()
+					continue
+				}
+
+				x.Status = InstallableAppInstalled
+				x.PrintStatus()
+				atomic.AddInt32(&success, 1)
+
+				core.UpdateTransactions(func(transactions *core.Transactions) error {
+					delete(transactions.PendingInstallations, x.App.Id)
+					return nil
+				})
 			}
-			return nil
-		})
-		if err := x.Install(); err != nil {
-			x.Status = InstallableAppFailed
-			x.PrintStatus()
-			utils.LogError(err)
-			break
-		} else {
-			x.Status = InstallableAppInstalled
-			x.PrintStatus()
-			success++
-		}
-		core.UpdateTransactions(func(transactions *core.Transactions) error {
-			delete(transactions.PendingInstallations, x.App.Id)
-			return nil
-		})
+		}()
+	}
+	for i := range apps {
+		jobs <- i
 	}
-	return success, count - success
+	close(jobs)
+	wg.Wait()
+
+	return int(success), count - int(success)
 }
 
 func (x *InstallableApp) Install() error {
@@ -165,15 +370,62 @@ func (x *InstallableApp) Install() error {
 		return err
 	}
 	x.Status = InstallableAppIntegrating
+	x.setPhase(core.PhaseIntegrating)
 	if err := x.Integrate(); err != nil {
 		return err
 	}
+	x.setPhase(core.PhaseSavingConfig)
 	if err := x.SaveConfig(); err != nil {
 		return err
 	}
+	x.removeBackup()
 	return nil
 }
 
+// setPhase advances this app's journal entry to phase, so a crash after
+// this point is recovered (by `pho recover`) according to how far the
+// install actually got.
+func (x *InstallableApp) setPhase(phase core.TransactionPhase) {
+	core.UpdateTransactions(func(transactions *core.Transactions) error {
+		pending, ok := transactions.PendingInstallations[x.App.Id]
+		if !ok {
+			return nil
+		}
+		pending.Phase = phase
+		transactions.PendingInstallations[x.App.Id] = pending
+		return nil
+	})
+}
+
+// rollback undoes whatever this app's journal entry recorded after a
+// failed install, then drops the entry so a subsequent `pho recover`
+// doesn't see it.
+func (x *InstallableApp) rollback() {
+	core.UpdateTransactions(func(transactions *core.Transactions) error {
+		pending, ok := transactions.PendingInstallations[x.App.Id]
+		if !ok {
+			return nil
+		}
+		if config, err := core.GetConfig(); err == nil {
+			if err := core.RollbackInstallation(x.App.Id, pending, config); err != nil {
+				utils.LogError(err)
+			}
+		}
+		delete(transactions.PendingInstallations, x.App.Id)
+		return nil
+	})
+}
+
+// downloadPartSuffix names the on-disk partial download next to its final
+// destination, so a --resume run can find and continue it.
+const downloadPartSuffix = ".part"
+
+// appImageBackupSuffix names where an existing AppImage is moved aside to
+// before a reinstall/upgrade overwrites it, so a failure afterward can
+// restore it instead of leaving the new (possibly broken) binary in place
+// mislabeled as the old version.
+const appImageBackupSuffix = ".bak"
+
 func (x *InstallableApp) Download() error {
 	if err := os.MkdirAll(x.App.Paths.Dir, os.ModePerm); err != nil {
 		return err
@@ -181,27 +433,114 @@ func (x *InstallableApp) Download() error {
 	if err := os.MkdirAll(path.Dir(x.App.Paths.Desktop), os.ModePerm); err != nil {
 		return err
 	}
-	tempFile, err := utils.CreateTempFile(x.App.Paths.AppImage)
-	if err != nil {
-		return err
+
+	partPath := x.App.Paths.AppImage + downloadPartSuffix
+	if !x.Resume {
+		os.Remove(partPath)
 	}
-	defer tempFile.Close()
-	data, err := x.Asset.Download()
+
+	err := downloader.Download(x.Asset.DownloadUrl, partPath, downloader.Options{
+		Parallel:   x.Parallel,
+		Retries:    x.Retries,
+		Resume:     x.Resume,
+		OnProgress: x.addProgress,
+	})
 	if err != nil {
+		os.Remove(partPath)
 		return err
 	}
-	defer data.Close()
-	mw := io.MultiWriter(tempFile, x)
-	_, err = io.Copy(mw, data)
+
+	verification, err := x.verify(partPath)
 	if err != nil {
+		os.Remove(partPath)
 		return err
 	}
-	if err = os.Rename(tempFile.Name(), x.App.Paths.AppImage); err != nil {
+	x.Verification = verification
+
+	if _, err := os.Stat(x.App.Paths.AppImage); err == nil {
+		// A previous AppImage already exists at this path (reinstall or
+		// upgrade): move it aside instead of overwriting it outright, so a
+		// failure in Integrate/SaveConfig below can still restore it.
+		backupPath := x.App.Paths.AppImage + appImageBackupSuffix
+		if err := os.Rename(x.App.Paths.AppImage, backupPath); err != nil {
+			return err
+		}
+		x.backupAppImage = backupPath
+		x.recordBackup(backupPath)
+	}
+
+	if err = os.Rename(partPath, x.App.Paths.AppImage); err != nil {
 		return err
 	}
 	return os.Chmod(x.App.Paths.AppImage, 0755)
 }
 
+// recordBackup persists backupPath in this app's journal entry, so a crash
+// between here and a successful/failed finish still knows a previous
+// AppImage was moved aside and must be restored rather than discarded.
+func (x *InstallableApp) recordBackup(backupPath string) {
+	core.UpdateTransactions(func(transactions *core.Transactions) error {
+		pending, ok := transactions.PendingInstallations[x.App.Id]
+		if !ok {
+			return nil
+		}
+		pending.BackupAppImage = backupPath
+		transactions.PendingInstallations[x.App.Id] = pending
+		return nil
+	})
+}
+
+// removeBackup discards the previous AppImage moved aside by Download,
+// once the new one has been fully installed and no longer needs it.
+func (x *InstallableApp) removeBackup() {
+	if x.backupAppImage == "" {
+		return
+	}
+	os.Remove(x.backupAppImage)
+}
+
+// verify checks the downloaded asset at path against a checksum and/or a
+// detached GPG signature. It re-reads the file once to compute digests,
+// since a parallel, chunked download has no single stream to hash inline.
+func (x *InstallableApp) verify(path string) (core.VerificationResult, error) {
+	if x.NoVerify {
+		return core.VerificationResult{Skipped: true}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return core.VerificationResult{}, err
+	}
+	defer file.Close()
+
+	sha256Sum := sha256.New()
+	sha512Sum := sha512.New()
+	if _, err := io.Copy(io.MultiWriter(sha256Sum, sha512Sum), file); err != nil {
+		return core.VerificationResult{}, err
+	}
+
+	result, err := core.VerifyAssetChecksum(x.Asset, x.Checksum, sha256Sum, sha512Sum)
+	if err != nil {
+		return result, fmt.Errorf("checksum verification failed: %w", err)
+	}
+
+	config, err := core.GetConfig()
+	if err != nil {
+		return result, err
+	}
+	signed, err := core.VerifyAssetSignature(path, x.Asset, config.TrustedGpgKeyrings)
+	if err != nil {
+		return result, fmt.Errorf("signature verification failed: %w", err)
+	}
+	result.Signed = signed
+
+	if x.RequireSignature && !signed {
+		return result, errors.New("no valid signature found for asset, but --require-signature was set")
+	}
+
+	return result, nil
+}
+
 func (x *InstallableApp) Integrate() error {
 	tempDir := path.Join(x.App.Paths.Dir, "temp")
 	err := os.Mkdir(tempDir, os.ModePerm)
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"github.com/zyrouge/pho/core"
+	"github.com/zyrouge/pho/utils"
+)
+
+var InstallGiteaCommand = cli.Command{
+	Name:    "gitea",
+	Aliases: []string{"codeberg"},
+	Usage:   "Install an application from Gitea or Codeberg",
+	Flags: append(sharedInstallFlags(), &cli.StringFlag{
+		Name:  "host",
+		Usage: "Self-hosted Gitea instance, e.g. https://git.company.com (defaults to Codeberg)",
+	}),
+	Action: func(ctx *cli.Context) error {
+		config, err := core.GetConfig()
+		if err != nil {
+			return err
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		args := ctx.Args()
+		if args.Len() == 0 {
+			return errors.New("no url specified")
+		}
+		if args.Len() > 1 {
+			return errors.New("unexpected excessive arguments")
+		}
+
+		url := args.Get(0)
+		appId := ctx.String("id")
+		appName := ctx.String("name")
+		host := ctx.String("host")
+
+		source := &core.GiteaSource{
+			Host:       host,
+			PreRelease: ctx.Bool("prerelease"),
+			TagName:    ctx.String("tag"),
+		}
+		isValidUrl, owner, repo := source.ParseRepoUrl(url)
+		utils.LogDebug(fmt.Sprintf("parsed gitea url valid: %v", isValidUrl))
+		if !isValidUrl {
+			return errors.New("invalid gitea repo url")
+		}
+		source.UserName = owner
+		source.RepoName = repo
+
+		if appName == "" {
+			appName = repo
+		}
+		if appId == "" {
+			appId = source.ConstructAppId(owner, repo)
+		}
+
+		return installGenericSource(config, reader, source, core.GiteaSourceId, appId, appName, installOptionsFromContext(ctx))
+	},
+}
@@ -39,6 +39,32 @@ var InstallGithubCommand = cli.Command{
 			Aliases: []string{"y"},
 			Usage:   "Automatically answer yes for questions",
 		},
+		&cli.BoolFlag{
+			Name:  "no-verify",
+			Usage: "Skip checksum and signature verification",
+		},
+		&cli.BoolFlag{
+			Name:  "require-signature",
+			Usage: "Fail if no valid GPG signature is found for the asset",
+		},
+		&cli.StringFlag{
+			Name:  "checksum",
+			Usage: "Expected SHA256 or SHA512 checksum (hex) of the asset",
+		},
+		&cli.IntFlag{
+			Name:  "parallel",
+			Usage: "Number of concurrent chunks to download the asset in",
+			Value: 1,
+		},
+		&cli.IntFlag{
+			Name:  "retries",
+			Usage: "Number of retries per chunk on transient download failures",
+			Value: 3,
+		},
+		&cli.BoolFlag{
+			Name:  "resume",
+			Usage: "Resume a previously interrupted download",
+		},
 	},
 	Action: func(ctx *cli.Context) error {
 		config, err := core.GetConfig()
@@ -60,13 +86,23 @@ var InstallGithubCommand = cli.Command{
 		appName := ctx.String("name")
 		tagName := ctx.String("tag")
 		prerelease := ctx.Bool("prerelease")
-		assumeYes := ctx.Bool("assume-yes")
+		opts := installOptions{
+			AssumeYes:        ctx.Bool("assume-yes"),
+			NoVerify:         ctx.Bool("no-verify"),
+			RequireSignature: ctx.Bool("require-signature"),
+			Checksum:         ctx.String("checksum"),
+			Parallel:         int(ctx.Int("parallel")),
+			Retries:          int(ctx.Int("retries")),
+			Resume:           ctx.Bool("resume"),
+		}
 		utils.LogDebug(fmt.Sprintf("argument url: %s", url))
 		utils.LogDebug(fmt.Sprintf("argument id: %s", appId))
 		utils.LogDebug(fmt.Sprintf("argument name: %s", appName))
 		utils.LogDebug(fmt.Sprintf("argument tag: %s", tagName))
 		utils.LogDebug(fmt.Sprintf("argument prerelease: %v", prerelease))
-		utils.LogDebug(fmt.Sprintf("argument assume-yes: %v", assumeYes))
+		utils.LogDebug(fmt.Sprintf("argument assume-yes: %v", opts.AssumeYes))
+		utils.LogDebug(fmt.Sprintf("argument no-verify: %v", opts.NoVerify))
+		utils.LogDebug(fmt.Sprintf("argument require-signature: %v", opts.RequireSignature))
 
 		isValidUrl, ghUsername, ghReponame := core.ParseGithubRepoUrl(url)
 		utils.LogDebug(fmt.Sprintf("parsed github url valid: %v", isValidUrl))
@@ -81,10 +117,6 @@ var InstallGithubCommand = cli.Command{
 		if appId == "" {
 			appId = core.ConstructAppId(ghUsername, ghReponame)
 		}
-		appId = utils.CleanId(appId)
-		if appId == "" {
-			return errors.New("invalid application id")
-		}
 
 		source := &core.GithubSource{
 			UserName:   ghUsername,
@@ -92,53 +124,70 @@ var InstallGithubCommand = cli.Command{
 			PreRelease: prerelease,
 			TagName:    tagName,
 		}
-		release, err := source.FetchAptRelease()
-		if err != nil {
-			return err
-		}
-		utils.LogDebug(fmt.Sprintf("selected github tag name: %s", release.TagName))
-
-		matchLevel, asset := core.ChooseAptAppImageAsset(
-			release.Assets,
-			func(x *core.GithubApiReleaseAsset) string {
-				return x.Name
-			},
-		)
-		if matchLevel == 0 {
-			return fmt.Errorf("no valid asset in github tag %s", release.TagName)
-		}
-		if matchLevel == 1 {
-			utils.LogWarning("no architecture specified in the asset name, cannot determine compatibility")
-		}
+		return installGithubSource(config, reader, source, appId, appName, opts)
+	},
+}
 
-		appPaths := core.GetAppPaths(config, appId, appName)
-		if _, ok := config.Installed[appId]; ok {
-			utils.LogWarning(fmt.Sprintf("application with id %s already exists", appId))
-			if !assumeYes {
-				proceed, err := utils.PromptYesNoInput(reader, "Do you want to re-install this application?")
-				if err != nil {
-					return err
-				}
-				if !proceed {
-					utils.LogWarning("aborted...")
-					return nil
-				}
-			}
-		}
+// installOptions collects the flags shared by every install subcommand
+// (and by hub-resolved installs), so the download/verification pipeline
+// only needs to be wired up once per source kind.
+type installOptions struct {
+	AssumeYes        bool
+	NoVerify         bool
+	RequireSignature bool
+	Checksum         string
+	Parallel         int
+	Retries          int
+	Resume           bool
+}
+
+// installGithubSource resolves the latest (or pinned) release of source,
+// confirms the install with the user and runs it. It is shared by the
+// `install github` subcommand and by hub-resolved installs (`pho install
+// <id>`), which construct the same core.GithubSource from the hub index.
+func installGithubSource(
+	config *core.Config,
+	reader *bufio.Reader,
+	source *core.GithubSource,
+	appId string,
+	appName string,
+	opts installOptions,
+) error {
+	appId = utils.CleanId(appId)
+	if appId == "" {
+		return errors.New("invalid application id")
+	}
+
+	release, err := source.FetchAptRelease()
+	if err != nil {
+		return err
+	}
+	utils.LogDebug(fmt.Sprintf("selected github tag name: %s", release.TagName))
+
+	matchLevel, asset := core.ChooseAptAppImageAsset(
+		release.Assets,
+		func(x *core.GithubApiReleaseAsset) string {
+			return x.Name
+		},
+	)
+	if matchLevel == 0 {
+		return fmt.Errorf("no valid asset in github tag %s", release.TagName)
+	}
+	if matchLevel == 1 {
+		utils.LogWarning("no architecture specified in the asset name, cannot determine compatibility")
+	}
+	coreAsset := asset.ToAsset()
+	coreAsset.Sha256Url, coreAsset.Sha512Url, coreAsset.DigestUrl, coreAsset.SignatureUrl = core.FindSiblingAssetUrls(
+		release.Assets, asset.Name,
+		func(x *core.GithubApiReleaseAsset) string { return x.Name },
+		func(x *core.GithubApiReleaseAsset) string { return x.DownloadUrl },
+	)
 
-		utils.LogLn()
-		summary := utils.NewLogTable()
-		summary.Add(utils.LogRightArrowPrefix, "Name", color.CyanString(appName))
-		summary.Add(utils.LogRightArrowPrefix, "Identifier", color.CyanString(appId))
-		summary.Add(utils.LogRightArrowPrefix, "Version", color.CyanString(release.TagName))
-		summary.Add(utils.LogRightArrowPrefix, "Filename", color.CyanString(asset.Name))
-		summary.Add(utils.LogRightArrowPrefix, "AppImage", color.CyanString(appPaths.AppImage))
-		summary.Add(utils.LogRightArrowPrefix, ".desktop file", color.CyanString(appPaths.Desktop))
-		summary.Print()
-		utils.LogLn()
-
-		if !assumeYes {
-			proceed, err := utils.PromptYesNoInput(reader, "Do you want to proceed?")
+	appPaths := core.GetAppPaths(config, appId, appName)
+	if _, ok := config.Installed[appId]; ok {
+		utils.LogWarning(fmt.Sprintf("application with id %s already exists", appId))
+		if !opts.AssumeYes {
+			proceed, err := utils.PromptYesNoInput(reader, "Do you want to re-install this application?")
 			if err != nil {
 				return err
 			}
@@ -147,34 +196,74 @@ var InstallGithubCommand = cli.Command{
 				return nil
 			}
 		}
+	}
+
+	utils.LogLn()
+	summary := utils.NewLogTable()
+	summary.Add(utils.LogRightArrowPrefix, "Name", color.CyanString(appName))
+	summary.Add(utils.LogRightArrowPrefix, "Identifier", color.CyanString(appId))
+	summary.Add(utils.LogRightArrowPrefix, "Version", color.CyanString(release.TagName))
+	summary.Add(utils.LogRightArrowPrefix, "Filename", color.CyanString(asset.Name))
+	summary.Add(utils.LogRightArrowPrefix, "AppImage", color.CyanString(appPaths.AppImage))
+	summary.Add(utils.LogRightArrowPrefix, ".desktop file", color.CyanString(appPaths.Desktop))
+	hasChecksum := opts.Checksum != "" || coreAsset.Sha256Url != "" || coreAsset.Sha512Url != "" || coreAsset.DigestUrl != ""
+	if opts.NoVerify {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.HiBlackString("skipped"))
+	} else if coreAsset.HasSignature() && hasChecksum {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.CyanString("checksum + signature"))
+	} else if coreAsset.HasSignature() {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.YellowString("signature only, no checksum available"))
+	} else if hasChecksum {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.YellowString("checksum only, asset is not signed"))
+	} else {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.YellowString("none, no checksum or signature available"))
+	}
+	summary.Print()
+	utils.LogLn()
 
-		app := &core.AppConfig{
-			Id:       appId,
-			Name:     appName,
-			AppImage: appPaths.AppImage,
-			Version:  release.TagName,
-			Source:   core.GithubSourceId,
+	if !opts.AssumeYes {
+		proceed, err := utils.PromptYesNoInput(reader, "Do you want to proceed?")
+		if err != nil {
+			return err
 		}
-		utils.LogLn()
-		installed, _ := InstallApps([]InstallableApp{{
-			App:    app,
-			Source: source,
-			Paths:  appPaths,
-			Asset:  asset.ToAsset(),
-		}})
-		if installed != 1 {
+		if !proceed {
+			utils.LogWarning("aborted...")
 			return nil
 		}
+	}
 
-		utils.LogLn()
-		utils.LogInfo(
-			fmt.Sprintf(
-				"%s Installed %s successfully!",
-				utils.LogTickPrefix,
-				color.CyanString(app.Name),
-			),
-		)
-
+	app := &core.AppConfig{
+		Id:       appId,
+		Name:     appName,
+		AppImage: appPaths.AppImage,
+		Version:  release.TagName,
+		Source:   core.GithubSourceId,
+	}
+	utils.LogLn()
+	installed, _ := InstallApps([]InstallableApp{{
+		App:              app,
+		Source:           source,
+		Paths:            appPaths,
+		Asset:            coreAsset,
+		NoVerify:         opts.NoVerify,
+		RequireSignature: opts.RequireSignature,
+		Checksum:         opts.Checksum,
+		Parallel:         opts.Parallel,
+		Retries:          opts.Retries,
+		Resume:           opts.Resume,
+	}})
+	if installed != 1 {
 		return nil
-	},
+	}
+
+	utils.LogLn()
+	utils.LogInfo(
+		fmt.Sprintf(
+			"%s Installed %s successfully!",
+			utils.LogTickPrefix,
+			color.CyanString(app.Name),
+		),
+	)
+
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"github.com/zyrouge/pho/core"
+	"github.com/zyrouge/pho/utils"
+)
+
+var InstallGitlabCommand = cli.Command{
+	Name:  "gitlab",
+	Usage: "Install an application from GitLab",
+	Flags: append(sharedInstallFlags(), &cli.StringFlag{
+		Name:  "host",
+		Usage: "Self-hosted GitLab instance, e.g. https://gitlab.company.com",
+	}),
+	Action: func(ctx *cli.Context) error {
+		config, err := core.GetConfig()
+		if err != nil {
+			return err
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		args := ctx.Args()
+		if args.Len() == 0 {
+			return errors.New("no url specified")
+		}
+		if args.Len() > 1 {
+			return errors.New("unexpected excessive arguments")
+		}
+
+		url := args.Get(0)
+		appId := ctx.String("id")
+		appName := ctx.String("name")
+		host := ctx.String("host")
+
+		source := &core.GitlabSource{
+			Host:       host,
+			PreRelease: ctx.Bool("prerelease"),
+			TagName:    ctx.String("tag"),
+		}
+		isValidUrl, owner, repo := source.ParseRepoUrl(url)
+		utils.LogDebug(fmt.Sprintf("parsed gitlab url valid: %v", isValidUrl))
+		if !isValidUrl {
+			return errors.New("invalid gitlab repo url")
+		}
+		source.UserName = owner
+		source.RepoName = repo
+
+		if appName == "" {
+			appName = repo
+		}
+		if appId == "" {
+			appId = source.ConstructAppId(owner, repo)
+		}
+
+		return installGenericSource(config, reader, source, core.GitlabSourceId, appId, appName, installOptionsFromContext(ctx))
+	},
+}
@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v3"
+	"github.com/zyrouge/pho/core"
+	"github.com/zyrouge/pho/utils"
+)
+
+// installGenericSource runs the same fetch -> choose-asset -> confirm ->
+// install pipeline as installGithubSource, but through the core.Source
+// interface, so GitLab/Gitea/SourceForge (and any future provider) don't
+// each need their own copy of it.
+func installGenericSource(
+	config *core.Config,
+	reader *bufio.Reader,
+	source core.Source,
+	sourceId core.SourceId,
+	appId string,
+	appName string,
+	opts installOptions,
+) error {
+	appId = utils.CleanId(appId)
+	if appId == "" {
+		return errors.New("invalid application id")
+	}
+
+	release, err := source.FetchAptRelease()
+	if err != nil {
+		return err
+	}
+	utils.LogDebug(fmt.Sprintf("selected release tag name: %s", release.TagName))
+
+	matchLevel, asset := core.ChooseAptAppImageAsset(
+		release.Assets,
+		func(x *core.SourceAsset) string {
+			return x.Name
+		},
+	)
+	if matchLevel == 0 {
+		return fmt.Errorf("no valid asset in release %s", release.TagName)
+	}
+	if matchLevel == 1 {
+		utils.LogWarning("no architecture specified in the asset name, cannot determine compatibility")
+	}
+	coreAsset := asset.ToAsset()
+	coreAsset.Sha256Url, coreAsset.Sha512Url, coreAsset.DigestUrl, coreAsset.SignatureUrl = core.FindSiblingAssetUrls(
+		release.Assets, asset.Name,
+		func(x *core.SourceAsset) string { return x.Name },
+		func(x *core.SourceAsset) string { return x.DownloadUrl },
+	)
+
+	appPaths := core.GetAppPaths(config, appId, appName)
+	if _, ok := config.Installed[appId]; ok {
+		utils.LogWarning(fmt.Sprintf("application with id %s already exists", appId))
+		if !opts.AssumeYes {
+			proceed, err := utils.PromptYesNoInput(reader, "Do you want to re-install this application?")
+			if err != nil {
+				return err
+			}
+			if !proceed {
+				utils.LogWarning("aborted...")
+				return nil
+			}
+		}
+	}
+
+	utils.LogLn()
+	summary := utils.NewLogTable()
+	summary.Add(utils.LogRightArrowPrefix, "Name", color.CyanString(appName))
+	summary.Add(utils.LogRightArrowPrefix, "Identifier", color.CyanString(appId))
+	summary.Add(utils.LogRightArrowPrefix, "Version", color.CyanString(release.TagName))
+	summary.Add(utils.LogRightArrowPrefix, "Filename", color.CyanString(asset.Name))
+	summary.Add(utils.LogRightArrowPrefix, "AppImage", color.CyanString(appPaths.AppImage))
+	summary.Add(utils.LogRightArrowPrefix, ".desktop file", color.CyanString(appPaths.Desktop))
+	hasChecksum := opts.Checksum != "" || coreAsset.Sha256Url != "" || coreAsset.Sha512Url != "" || coreAsset.DigestUrl != ""
+	if opts.NoVerify {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.HiBlackString("skipped"))
+	} else if coreAsset.HasSignature() && hasChecksum {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.CyanString("checksum + signature"))
+	} else if coreAsset.HasSignature() {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.YellowString("signature only, no checksum available"))
+	} else if hasChecksum {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.YellowString("checksum only, asset is not signed"))
+	} else {
+		summary.Add(utils.LogRightArrowPrefix, "Verification", color.YellowString("none, no checksum or signature available"))
+	}
+	summary.Print()
+	utils.LogLn()
+
+	if !opts.AssumeYes {
+		proceed, err := utils.PromptYesNoInput(reader, "Do you want to proceed?")
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			utils.LogWarning("aborted...")
+			return nil
+		}
+	}
+
+	app := &core.AppConfig{
+		Id:       appId,
+		Name:     appName,
+		AppImage: appPaths.AppImage,
+		Version:  release.TagName,
+		Source:   sourceId,
+	}
+	utils.LogLn()
+	installed, _ := InstallApps([]InstallableApp{{
+		App:              app,
+		Source:           source,
+		Paths:            appPaths,
+		Asset:            coreAsset,
+		NoVerify:         opts.NoVerify,
+		RequireSignature: opts.RequireSignature,
+		Checksum:         opts.Checksum,
+		Parallel:         opts.Parallel,
+		Retries:          opts.Retries,
+		Resume:           opts.Resume,
+	}})
+	if installed != 1 {
+		return nil
+	}
+
+	utils.LogLn()
+	utils.LogInfo(
+		fmt.Sprintf(
+			"%s Installed %s successfully!",
+			utils.LogTickPrefix,
+			color.CyanString(app.Name),
+		),
+	)
+	return nil
+}
+
+// installOptionsFromContext reads the install flags shared across every
+// `install <provider>` subcommand.
+func installOptionsFromContext(ctx *cli.Context) installOptions {
+	return installOptions{
+		AssumeYes:        ctx.Bool("assume-yes"),
+		NoVerify:         ctx.Bool("no-verify"),
+		RequireSignature: ctx.Bool("require-signature"),
+		Checksum:         ctx.String("checksum"),
+		Parallel:         int(ctx.Int("parallel")),
+		Retries:          int(ctx.Int("retries")),
+		Resume:           ctx.Bool("resume"),
+	}
+}
+
+// sharedInstallFlags are the flags common to every `install <provider>`
+// subcommand, beyond whatever provider-specific flags (e.g. --host) it adds.
+func sharedInstallFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "id",
+			Usage: "Application identifier",
+		},
+		&cli.StringFlag{
+			Name:  "name",
+			Usage: "Application name",
+		},
+		&cli.StringFlag{
+			Name:  "tag",
+			Usage: "Tag name",
+		},
+		&cli.BoolFlag{
+			Name:  "prerelease",
+			Usage: "Select pre-release tags",
+			Value: false,
+		},
+		&cli.BoolFlag{
+			Name:    "assume-yes",
+			Aliases: []string{"y"},
+			Usage:   "Automatically answer yes for questions",
+		},
+		&cli.BoolFlag{
+			Name:  "no-verify",
+			Usage: "Skip checksum and signature verification",
+		},
+		&cli.BoolFlag{
+			Name:  "require-signature",
+			Usage: "Fail if no valid GPG signature is found for the asset",
+		},
+		&cli.StringFlag{
+			Name:  "checksum",
+			Usage: "Expected SHA256 or SHA512 checksum (hex) of the asset",
+		},
+		&cli.IntFlag{
+			Name:  "parallel",
+			Usage: "Number of concurrent chunks to download the asset in",
+			Value: 1,
+		},
+		&cli.IntFlag{
+			Name:  "retries",
+			Usage: "Number of retries per chunk on transient download failures",
+			Value: 3,
+		},
+		&cli.BoolFlag{
+			Name:  "resume",
+			Usage: "Resume a previously interrupted download",
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"github.com/zyrouge/pho/core"
+	"github.com/zyrouge/pho/utils"
+)
+
+var InstallSourceforgeCommand = cli.Command{
+	Name:    "sourceforge",
+	Aliases: []string{"sf"},
+	Usage:   "Install an application from SourceForge",
+	Flags:   sharedInstallFlags(),
+	Action: func(ctx *cli.Context) error {
+		config, err := core.GetConfig()
+		if err != nil {
+			return err
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		args := ctx.Args()
+		if args.Len() == 0 {
+			return errors.New("no url specified")
+		}
+		if args.Len() > 1 {
+			return errors.New("unexpected excessive arguments")
+		}
+
+		url := args.Get(0)
+		appId := ctx.String("id")
+		appName := ctx.String("name")
+
+		source := &core.SourceforgeSource{
+			TagName: ctx.String("tag"),
+		}
+		isValidUrl, _, project := source.ParseRepoUrl(url)
+		utils.LogDebug(fmt.Sprintf("parsed sourceforge url valid: %v", isValidUrl))
+		if !isValidUrl {
+			return errors.New("invalid sourceforge project url")
+		}
+		source.ProjectName = project
+
+		if appName == "" {
+			appName = project
+		}
+		if appId == "" {
+			appId = source.ConstructAppId("", project)
+		}
+
+		return installGenericSource(config, reader, source, core.SourceforgeSourceId, appId, appName, installOptionsFromContext(ctx))
+	},
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v3"
+	"github.com/zyrouge/pho/core"
+	"github.com/zyrouge/pho/utils"
+)
+
+var RecoverCommand = cli.Command{
+	Name:  "recover",
+	Usage: "Finish or roll back installs interrupted by a crash",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "assume-yes",
+			Aliases: []string{"y"},
+			Usage:   "Automatically answer yes for questions",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		transactions, err := core.ReadTransactions()
+		if err != nil {
+			return err
+		}
+		if len(transactions.PendingInstallations) == 0 {
+			utils.LogInfo("Nothing to recover")
+			return nil
+		}
+
+		config, err := core.GetConfig()
+		if err != nil {
+			return err
+		}
+		reader := bufio.NewReader(os.Stdin)
+		assumeYes := ctx.Bool("assume-yes")
+
+		for appId, pending := range transactions.PendingInstallations {
+			action, pastTense := "roll back", "rolled back"
+			if pending.CanFinish() {
+				action, pastTense = "finish", "finished"
+			}
+			utils.LogWarning(
+				fmt.Sprintf(
+					"Found an interrupted install of %s (phase: %s)",
+					color.CyanString(appId),
+					pending.Phase,
+				),
+			)
+			if !assumeYes {
+				proceed, err := utils.PromptYesNoInput(reader, fmt.Sprintf("Do you want to %s this install?", action))
+				if err != nil {
+					return err
+				}
+				if !proceed {
+					continue
+				}
+			}
+
+			var recoverErr error
+			if pending.CanFinish() {
+				recoverErr = core.FinishInstallation(appId, pending, config)
+			} else {
+				recoverErr = core.RollbackInstallation(appId, pending, config)
+			}
+			if recoverErr != nil {
+				utils.LogError(recoverErr)
+				continue
+			}
+
+			core.UpdateTransactions(func(transactions *core.Transactions) error {
+				delete(transactions.PendingInstallations, appId)
+				return nil
+			})
+			utils.LogInfo(
+				fmt.Sprintf("%s %s %s", utils.LogTickPrefix, color.CyanString(appId), pastTense),
+			)
+		}
+
+		return nil
+	},
+}
@@ -1,10 +1,13 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"runtime"
 	"strings"
 
 	"github.com/fatih/color"
@@ -13,43 +16,97 @@ import (
 	"github.com/zyrouge/pho/utils"
 )
 
+// selfUpdateOldSuffix names the previous binary kept around after a swap,
+// so `pho self-update --rollback` can restore it.
+const selfUpdateOldSuffix = ".old"
+
 var SelfUpdateCommand = cli.Command{
 	Name:    "self-update",
 	Aliases: []string{"self-upgrade"},
 	Usage:   fmt.Sprintf("Update %s", core.AppName),
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "check",
+			Usage: "Report the latest available version without installing it",
+		},
+		&cli.StringFlag{
+			Name:  "channel",
+			Usage: "Release channel to update from (stable or prerelease)",
+			Value: "stable",
+		},
+		&cli.BoolFlag{
+			Name:  "rollback",
+			Usage: "Restore the binary kept from the previous self-update",
+		},
+	},
 	Action: func(ctx *cli.Context) error {
-		release, err := core.GithubApiFetchLatestRelease(core.AppGithubOwner, core.AppGithubRepo)
+		if ctx.Bool("rollback") {
+			return selfUpdateRollback()
+		}
+
+		source := &core.GithubSource{
+			UserName:   core.AppGithubOwner,
+			RepoName:   core.AppGithubRepo,
+			PreRelease: ctx.String("channel") == "prerelease",
+		}
+		release, err := source.FetchAptRelease()
 		if err != nil {
 			return err
 		}
-		arch := utils.GetSystemArch()
-		var asset *core.GithubApiReleaseAsset
-		for _, x := range release.Assets {
-			if strings.HasSuffix(x.Name, arch) {
-				asset = &x
-			}
-		}
-		if asset == nil {
-			return fmt.Errorf(
-				"unable to find appropriate binary from release %s",
-				release.TagName,
+
+		if ctx.Bool("check") {
+			utils.LogInfo(
+				fmt.Sprintf("Latest version: %s (current: %s)", color.CyanString(release.TagName), core.AppVersion),
 			)
+			return nil
 		}
-		utils.LogInfo(fmt.Sprintf("Updating to version %s...", color.CyanString(release.TagName)))
-		data, err := http.Get(asset.DownloadUrl)
+
+		asset, err := selectSelfUpdateAsset(release.Assets)
 		if err != nil {
 			return err
 		}
-		defer data.Body.Close()
-		file, err := os.Create(os.Args[0])
+
+		exePath, err := os.Executable()
 		if err != nil {
 			return err
 		}
-		defer file.Close()
-		_, err = io.Copy(file, data.Body)
+		tempPath := exePath + ".update"
+		oldPath := exePath + selfUpdateOldSuffix
+
+		utils.LogInfo(fmt.Sprintf("Updating to version %s...", color.CyanString(release.TagName)))
+		sum, err := downloadSelfUpdateAsset(asset.DownloadUrl, tempPath)
 		if err != nil {
+			os.Remove(tempPath)
+			return err
+		}
+
+		if checksumAsset := findAssetNamed(release.Assets, asset.Name+".sha256"); checksumAsset != nil {
+			expected, err := fetchExpectedChecksum(checksumAsset.DownloadUrl)
+			if err != nil {
+				os.Remove(tempPath)
+				return err
+			}
+			if !strings.EqualFold(expected, sum) {
+				os.Remove(tempPath)
+				return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, sum)
+			}
+		} else {
+			utils.LogWarning("no checksum published for this release, skipping verification")
+		}
+
+		if err := os.Chmod(tempPath, 0755); err != nil {
+			os.Remove(tempPath)
 			return err
 		}
+		if err := os.Rename(exePath, oldPath); err != nil {
+			os.Remove(tempPath)
+			return err
+		}
+		if err := os.Rename(tempPath, exePath); err != nil {
+			os.Rename(oldPath, exePath)
+			return err
+		}
+
 		utils.LogInfo(
 			fmt.Sprintf(
 				"%s Updated to version %s successfully!",
@@ -57,7 +114,126 @@ var SelfUpdateCommand = cli.Command{
 				color.CyanString(release.TagName),
 			),
 		)
-
 		return nil
 	},
-}
\ No newline at end of file
+}
+
+// selfUpdateSiblingSuffixes lists the non-binary asset suffixes a release
+// publishes alongside the real update binary (see downloadSelfUpdateAsset
+// and fetchExpectedChecksum), so selectSelfUpdateAsset doesn't mistake one
+// of them for the binary itself.
+var selfUpdateSiblingSuffixes = []string{".sha256", ".sha512", ".asc", ".sig"}
+
+// selectSelfUpdateAsset picks the release asset matching the running
+// binary's OS and architecture, rather than trusting a naive filename
+// suffix match (which breaks as soon as a release adds e.g. a checksum or
+// signature asset alongside the binary, since those also contain the OS
+// and arch substrings of the binary they describe).
+func selectSelfUpdateAsset(assets []core.GithubApiReleaseAsset) (*core.GithubApiReleaseAsset, error) {
+	goos := runtime.GOOS
+	arch := utils.GetSystemArch()
+	for i := range assets {
+		name := strings.ToLower(assets[i].Name)
+		if isSelfUpdateSibling(name) {
+			continue
+		}
+		if strings.Contains(name, goos) && strings.Contains(name, arch) {
+			return &assets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find a release asset for %s/%s", goos, arch)
+}
+
+// isSelfUpdateSibling reports whether name is a checksum/signature file
+// published alongside the update binary rather than the binary itself.
+func isSelfUpdateSibling(name string) bool {
+	for _, suffix := range selfUpdateSiblingSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func findAssetNamed(assets []core.GithubApiReleaseAsset, name string) *core.GithubApiReleaseAsset {
+	for i := range assets {
+		if assets[i].Name == name {
+			return &assets[i]
+		}
+	}
+	return nil
+}
+
+// downloadSelfUpdateAsset streams url into a sibling temp file next to the
+// running binary (rather than into the running binary itself), returning
+// its hex-encoded SHA256 so the caller can verify it before swapping it in.
+func downloadSelfUpdateAsset(url string, tempPath string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("failed to download update: status %d", resp.StatusCode)
+	}
+
+	file, err := os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	sum := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, sum), resp.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// fetchExpectedChecksum reads a `<hex>  <filename>` style `.sha256` asset
+// and returns just the hex digest.
+func fetchExpectedChecksum(url string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("failed to download checksum: status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum file")
+	}
+	return fields[0], nil
+}
+
+// selfUpdateRollback swaps the `.old` binary kept from the last
+// self-update back into place.
+func selfUpdateRollback() error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	oldPath := exePath + selfUpdateOldSuffix
+	if _, err := os.Stat(oldPath); err != nil {
+		return fmt.Errorf("no previous version to roll back to")
+	}
+
+	currentPath := exePath + ".rollback"
+	if err := os.Rename(exePath, currentPath); err != nil {
+		return err
+	}
+	if err := os.Rename(oldPath, exePath); err != nil {
+		os.Rename(currentPath, exePath)
+		return err
+	}
+	os.Remove(currentPath)
+
+	utils.LogInfo(fmt.Sprintf("%s Rolled back to the previous version", utils.LogTickPrefix))
+	return nil
+}
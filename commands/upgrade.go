@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v3"
+	"github.com/zyrouge/pho/core"
+	"github.com/zyrouge/pho/core/hub"
+	"github.com/zyrouge/pho/utils"
+)
+
+var UpgradeCommand = cli.Command{
+	Name:  "upgrade",
+	Usage: "Upgrade an installed application",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "all",
+			Usage: "Upgrade every installed application that is tracked in the hub",
+		},
+		&cli.BoolFlag{
+			Name:    "assume-yes",
+			Aliases: []string{"y"},
+			Usage:   "Automatically answer yes for questions",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		config, err := core.GetConfig()
+		if err != nil {
+			return err
+		}
+		index, err := hub.Load()
+		if err != nil {
+			return err
+		}
+
+		all := ctx.Bool("all")
+		args := ctx.Args()
+		var ids []string
+		switch {
+		case all:
+			for id := range config.Installed {
+				ids = append(ids, id)
+			}
+		case args.Len() == 1:
+			ids = []string{args.Get(0)}
+		default:
+			return errors.New("expected exactly one application id, or --all")
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		opts := installOptions{AssumeYes: ctx.Bool("assume-yes")}
+
+		for _, id := range ids {
+			item, ok := index.Get(id)
+			if !ok {
+				utils.LogWarning(fmt.Sprintf("%s is not tracked in the hub, skipping", id))
+				continue
+			}
+			installed, err := core.ReadAppConfig(config, id)
+			if err != nil {
+				utils.LogError(err)
+				continue
+			}
+
+			source, latest, err := item.LatestVersion()
+			if err != nil {
+				utils.LogError(err)
+				continue
+			}
+			if installed != nil && installed.Version == latest {
+				utils.LogInfo(fmt.Sprintf("%s is already up to date (%s)", color.CyanString(id), installed.Version))
+				continue
+			}
+
+			// installGithubSource runs the same InstallApps pipeline as
+			// `pho install github`, so an interrupted upgrade is journaled
+			// and recoverable exactly like a fresh install.
+			if err := installGithubSource(config, reader, source, item.Id, item.Name, opts); err != nil {
+				utils.LogError(err)
+			}
+		}
+		return nil
+	},
+}
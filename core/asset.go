@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Asset is a provider-agnostic downloadable release asset. Besides the
+// download itself, it carries whatever sibling checksum/signature files
+// FindSiblingAssetUrls discovered alongside it, so the install pipeline can
+// verify what it downloads without needing to know which provider it came
+// from.
+type Asset struct {
+	Name        string
+	DownloadUrl string
+	Size        int64
+
+	// Sha256Url/Sha512Url/DigestUrl point at a sibling file publishing a
+	// `<hex digest>  <filename>` style checksum for this asset (a release
+	// usually only publishes one). Verification prefers Sha512Url, then
+	// Sha256Url, then DigestUrl. DigestUrl covers the common "<name>.DIGEST"
+	// convention, whose digest length determines which algorithm it used.
+	Sha256Url string
+	Sha512Url string
+	DigestUrl string
+	// SignatureUrl points at a detached GPG signature (`.asc`/`.sig`) for
+	// this asset, if one was published alongside it.
+	SignatureUrl string
+}
+
+// Download fetches the asset's contents over HTTP. Callers that need to
+// verify a checksum/signature should read the whole body to a temp file
+// first, as InstallableApp.Download does, rather than trusting this stream
+// directly.
+func (x *Asset) Download() (io.ReadCloser, error) {
+	resp, err := http.Get(x.DownloadUrl)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: status %d", x.Name, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// HasSignature reports whether a detached GPG signature was discovered
+// alongside this asset.
+func (x *Asset) HasSignature() bool {
+	return x.SignatureUrl != ""
+}
+
+// FindSiblingAssetUrls scans a release's assets for the checksum/signature
+// files providers commonly publish alongside a main asset (e.g.
+// "app.AppImage.sha256", "app.AppImage.sig"), using nameOf/urlOf to read
+// each provider's own asset type generically.
+func FindSiblingAssetUrls[T any](assets []T, mainName string, nameOf func(*T) string, urlOf func(*T) string) (sha256Url, sha512Url, digestUrl, signatureUrl string) {
+	for i := range assets {
+		switch nameOf(&assets[i]) {
+		case mainName + ".sha256":
+			sha256Url = urlOf(&assets[i])
+		case mainName + ".sha512":
+			sha512Url = urlOf(&assets[i])
+		case mainName + ".DIGEST":
+			digestUrl = urlOf(&assets[i])
+		case mainName + ".asc", mainName + ".sig":
+			signatureUrl = urlOf(&assets[i])
+		}
+	}
+	return
+}
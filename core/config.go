@@ -0,0 +1,75 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Config is the user's global pho configuration, persisted at
+// ~/.config/pho/config.json.
+type Config struct {
+	// Installed maps an installed app's id to its directory.
+	Installed map[string]string `json:"installed"`
+	// HubUrls lists the Git or HTTPS hub sources `pho hub update` syncs.
+	HubUrls []string `json:"hubUrls,omitempty"`
+	// TrustedGpgKeyrings lists paths to armored public key files or GPG
+	// keyrings used to verify a signed asset's detached signature.
+	TrustedGpgKeyrings []string `json:"trustedGpgKeyrings,omitempty"`
+}
+
+// ConfigPath returns ~/.config/pho/config.json.
+func ConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pho", "config.json"), nil
+}
+
+// ReadConfig reads the config file, returning an empty one if it doesn't
+// exist yet.
+func ReadConfig() (*Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{Installed: map[string]string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	if config.Installed == nil {
+		config.Installed = map[string]string{}
+	}
+	return &config, nil
+}
+
+// GetConfig is an alias for ReadConfig, kept for the many call sites that
+// just want the current config without caring that it is re-read every
+// time.
+func GetConfig() (*Config, error) {
+	return ReadConfig()
+}
+
+// SaveConfig persists the config, creating parent directories as needed.
+func SaveConfig(config *Config) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,231 @@
+// Package downloader implements a resumable, retrying, optionally parallel
+// HTTP downloader used by the install commands. It is deliberately small:
+// it knows nothing about AppImages, assets or sources, only how to get a
+// URL onto disk as reliably as possible.
+package downloader
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Options configures a single download.
+type Options struct {
+	// Parallel is the number of concurrent range requests to split the
+	// download into. Values <= 1 disable chunking.
+	Parallel int
+	// Retries is the number of additional attempts made per chunk after a
+	// transient failure, with exponential backoff between attempts.
+	Retries int
+	// Resume continues from the existing contents of Dest instead of
+	// restarting the download from scratch.
+	Resume bool
+	// OnProgress is called with the number of newly written bytes every
+	// time a chunk writes to disk. It may be called concurrently.
+	OnProgress func(n int64)
+}
+
+const (
+	minChunkSize   = 8 * 1024 * 1024 // do not bother splitting smaller than 8MB
+	retryBaseDelay = 500 * time.Millisecond
+)
+
+// Download fetches url into dest, honouring Options. dest is written to
+// directly (at the offsets needed for resume/parallel chunks), so callers
+// that want atomic replace semantics should download into a temp file and
+// rename it into place themselves, as InstallableApp.Download does.
+func Download(url string, dest string, opts Options) error {
+	size, acceptsRanges, err := probe(url)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var startAt int64
+	if opts.Resume && acceptsRanges {
+		if stat, err := file.Stat(); err == nil {
+			startAt = stat.Size()
+		}
+	} else {
+		if err := file.Truncate(0); err != nil {
+			return err
+		}
+	}
+	if size > 0 && startAt >= size {
+		return nil
+	}
+
+	if !acceptsRanges {
+		// The server ignores Range headers, so any resume is impossible: a
+		// request from a non-zero offset would still return the full body,
+		// which downloadRange would then append after the stale bytes we
+		// just truncated away. startAt is always 0 here.
+		return retry(opts.Retries, func() error {
+			return downloadRange(url, file, startAt, -1, opts.OnProgress)
+		})
+	}
+	if size <= 0 {
+		return retry(opts.Retries, func() error {
+			return downloadRange(url, file, startAt, -1, opts.OnProgress)
+		})
+	}
+
+	parallel := opts.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	remaining := size - startAt
+	if parallel == 1 || remaining < minChunkSize {
+		return retry(opts.Retries, func() error {
+			return downloadRange(url, file, startAt, size-1, opts.OnProgress)
+		})
+	}
+
+	return downloadChunks(url, file, startAt, size, parallel, opts)
+}
+
+// probe issues a HEAD request to learn the content length and whether the
+// server supports byte-range requests.
+func probe(url string) (size int64, acceptsRanges bool, err error) {
+	resp, err := http.Head(url)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return 0, false, fmt.Errorf("failed to probe %s: status %d", url, resp.StatusCode)
+	}
+	return resp.ContentLength, resp.Header.Get("Accept-Ranges") == "bytes", nil
+}
+
+// downloadChunks splits [startAt, size) into `parallel` byte ranges and
+// downloads them concurrently, each chunk retried independently.
+func downloadChunks(url string, file *os.File, startAt, size int64, parallel int, opts Options) error {
+	chunkSize := (size - startAt) / int64(parallel)
+	if chunkSize < 1 {
+		chunkSize = size - startAt
+	}
+
+	type chunk struct{ from, to int64 }
+	chunks := make([]chunk, 0, parallel)
+	for from := startAt; from < size; from += chunkSize {
+		to := from + chunkSize - 1
+		if to >= size-1 || len(chunks) == parallel-1 {
+			to = size - 1
+		}
+		chunks = append(chunks, chunk{from, to})
+		if to == size-1 {
+			break
+		}
+	}
+
+	errs := make(chan error, len(chunks))
+	for _, c := range chunks {
+		go func(c chunk) {
+			errs <- retry(opts.Retries, func() error {
+				return downloadRangeAt(url, file, c.from, c.to, opts.OnProgress)
+			})
+		}(c)
+	}
+
+	var firstErr error
+	for range chunks {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// downloadRange requests [from, to] (to == -1 means "to EOF") and writes
+// the response body to file starting at offset from.
+func downloadRange(url string, file *os.File, from, to int64, onProgress func(int64)) error {
+	if _, err := file.Seek(from, io.SeekStart); err != nil {
+		return err
+	}
+	resp, err := rangeRequest(url, from, to)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return copyWithProgress(file, resp.Body, onProgress)
+}
+
+// downloadRangeAt is identical to downloadRange but writes via WriteAt so
+// concurrent chunks can share the same *os.File safely.
+func downloadRangeAt(url string, file *os.File, from, to int64, onProgress func(int64)) error {
+	resp, err := rangeRequest(url, from, to)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	w := io.NewOffsetWriter(file, from)
+	return copyWithProgress(w, resp.Body, onProgress)
+}
+
+func rangeRequest(url string, from, to int64) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if to >= 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", from, to))
+	} else if from > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", from))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download failed: status %d", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func copyWithProgress(w io.Writer, r io.Reader, onProgress func(int64)) error {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			if onProgress != nil {
+				onProgress(int64(n))
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// retry runs fn, retrying up to `times` additional attempts with
+// exponential backoff on transient (network/status) failures.
+func retry(times int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= times; attempt++ {
+		if attempt > 0 {
+			time.Sleep(retryBaseDelay * time.Duration(math.Pow(2, float64(attempt-1))))
+		}
+		if err = fn(); err == nil {
+			return nil
+		}
+	}
+	return errors.Join(errors.New("exhausted retries"), err)
+}
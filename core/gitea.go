@@ -0,0 +1,101 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var giteaRepoUrlPattern = regexp.MustCompile(`^https?://([^/]+)/([\w.\-]+)/([\w.\-]+?)(?:\.git)?/?$`)
+
+// GiteaSource installs from a Gitea (or Codeberg) repository's releases.
+// Host defaults to codeberg.org; set it to point at a self-hosted instance
+// (`--host https://git.company.com`).
+type GiteaSource struct {
+	Host       string
+	UserName   string
+	RepoName   string
+	PreRelease bool
+	TagName    string
+}
+
+type giteaApiReleaseAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadUrl string `json:"browser_download_url"`
+	Size               int64  `json:"size"`
+}
+
+type giteaApiRelease struct {
+	TagName    string                 `json:"tag_name"`
+	Prerelease bool                   `json:"prerelease"`
+	Assets     []giteaApiReleaseAsset `json:"assets"`
+}
+
+func (x *GiteaSource) host() string {
+	if x.Host != "" {
+		return strings.TrimSuffix(x.Host, "/")
+	}
+	return "https://codeberg.org"
+}
+
+func (x *GiteaSource) FetchAptRelease() (*SourceRelease, error) {
+	var endpoint string
+	if x.TagName != "" {
+		endpoint = fmt.Sprintf("%s/api/v1/repos/%s/%s/releases/tags/%s", x.host(), x.UserName, x.RepoName, url.PathEscape(x.TagName))
+	} else {
+		endpoint = fmt.Sprintf("%s/api/v1/repos/%s/%s/releases/latest", x.host(), x.UserName, x.RepoName)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := os.Getenv("PHO_GITEA_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("gitea api request failed: status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var release giteaApiRelease
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, err
+	}
+	if release.Prerelease && !x.PreRelease {
+		return nil, fmt.Errorf("release %s is a prerelease, pass --prerelease to allow it", release.TagName)
+	}
+
+	assets := make([]SourceAsset, 0, len(release.Assets))
+	for _, asset := range release.Assets {
+		assets = append(assets, SourceAsset{Name: asset.Name, DownloadUrl: asset.BrowserDownloadUrl, Size: asset.Size})
+	}
+	return &SourceRelease{TagName: release.TagName, Assets: assets}, nil
+}
+
+func (x *GiteaSource) ParseRepoUrl(repoUrl string) (bool, string, string) {
+	match := giteaRepoUrlPattern.FindStringSubmatch(repoUrl)
+	if match == nil {
+		return false, "", ""
+	}
+	if x.Host != "" && !strings.Contains(x.Host, match[1]) {
+		return false, "", ""
+	}
+	return true, match[2], match[3]
+}
+
+func (x *GiteaSource) ConstructAppId(owner string, repo string) string {
+	return fmt.Sprintf("%s-%s-gitea", owner, repo)
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var gitlabRepoUrlPattern = regexp.MustCompile(`^https?://([^/]+)/([\w.\-]+)/([\w.\-]+?)(?:\.git)?/?$`)
+
+// GitlabSource installs from a GitLab (or self-hosted GitLab) project's
+// releases. Host defaults to gitlab.com; set it to point at a
+// self-hosted instance (`--host https://gitlab.company.com`).
+type GitlabSource struct {
+	Host       string
+	UserName   string
+	RepoName   string
+	PreRelease bool
+	TagName    string
+}
+
+type gitlabApiReleaseLink struct {
+	Name           string `json:"name"`
+	DirectAssetUrl string `json:"direct_asset_url"`
+}
+
+type gitlabApiRelease struct {
+	TagName         string `json:"tag_name"`
+	UpcomingRelease bool   `json:"upcoming_release"`
+	Assets          struct {
+		Links []gitlabApiReleaseLink `json:"links"`
+	} `json:"assets"`
+}
+
+func (x *GitlabSource) host() string {
+	if x.Host != "" {
+		return strings.TrimSuffix(x.Host, "/")
+	}
+	return "https://gitlab.com"
+}
+
+func (x *GitlabSource) FetchAptRelease() (*SourceRelease, error) {
+	project := url.QueryEscape(fmt.Sprintf("%s/%s", x.UserName, x.RepoName))
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/releases", x.host(), project)
+	if x.TagName != "" {
+		endpoint += "/" + url.PathEscape(x.TagName)
+	} else {
+		endpoint += "/permalink/latest"
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := os.Getenv("PHO_GITLAB_TOKEN"); token != "" {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("gitlab api request failed: status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var release gitlabApiRelease
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, err
+	}
+	if release.UpcomingRelease && !x.PreRelease {
+		return nil, fmt.Errorf("release %s is an upcoming/pre-release, pass --prerelease to allow it", release.TagName)
+	}
+
+	assets := make([]SourceAsset, 0, len(release.Assets.Links))
+	for _, link := range release.Assets.Links {
+		assets = append(assets, SourceAsset{Name: link.Name, DownloadUrl: link.DirectAssetUrl})
+	}
+	return &SourceRelease{TagName: release.TagName, Assets: assets}, nil
+}
+
+func (x *GitlabSource) ParseRepoUrl(repoUrl string) (bool, string, string) {
+	match := gitlabRepoUrlPattern.FindStringSubmatch(repoUrl)
+	if match == nil {
+		return false, "", ""
+	}
+	if x.Host != "" && !strings.Contains(x.Host, match[1]) {
+		return false, "", ""
+	}
+	return true, match[2], match[3]
+}
+
+func (x *GitlabSource) ConstructAppId(owner string, repo string) string {
+	return fmt.Sprintf("%s-%s-gitlab", owner, repo)
+}
@@ -0,0 +1,200 @@
+// Package hub implements a package-manager-style local index of known
+// applications ("hub items"), synced from one or more configurable Git or
+// HTTPS sources. It lets `pho install <id>` resolve an id such as
+// "obsidian" to a concrete source without the caller having to know the
+// upstream GitHub/GitLab repo.
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zyrouge/pho/core"
+)
+
+// SourceKind identifies which kind of Source a HubItem resolves to. Only
+// GithubSourceKind is resolvable today; the rest are recorded so the index
+// format doesn't need to change as more sources are supported.
+type SourceKind string
+
+const (
+	GithubSourceKind      SourceKind = "github"
+	GitlabSourceKind      SourceKind = "gitlab"
+	GiteaSourceKind       SourceKind = "gitea"
+	SourceforgeSourceKind SourceKind = "sourceforge"
+)
+
+// HubItem is one entry in the hub index: enough to resolve a source
+// without the caller giving a raw URL. AssetPattern and ChecksumUrl are
+// stored in the index but not used when installing: the release asset is
+// chosen by the same name heuristics `install github` uses for a raw URL.
+type HubItem struct {
+	Id           string     `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description,omitempty"`
+	Source       SourceKind `json:"source"`
+	Owner        string     `json:"owner"`
+	Repo         string     `json:"repo"`
+	AssetPattern string     `json:"assetPattern,omitempty"`
+	ChecksumUrl  string     `json:"checksumUrl,omitempty"`
+	Disabled     bool       `json:"disabled,omitempty"`
+}
+
+// ToGithubSource resolves the item to a core.GithubSource. It is the only
+// resolvable kind; items of any other SourceKind return an error, even
+// where core has a Source implementation for that provider.
+func (x *HubItem) ToGithubSource() (*core.GithubSource, error) {
+	if x.Source != GithubSourceKind {
+		return nil, fmt.Errorf("hub item %q uses unsupported source %q", x.Id, x.Source)
+	}
+	return &core.GithubSource{
+		UserName: x.Owner,
+		RepoName: x.Repo,
+	}, nil
+}
+
+// Index is the on-disk hub index: a flat map of id to HubItem, merged from
+// every configured hub URL.
+type Index struct {
+	Items map[string]HubItem `json:"items"`
+}
+
+// IndexPath returns ~/.config/pho/hub/index.json.
+func IndexPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pho", "hub", "index.json"), nil
+}
+
+// Load reads the local hub index, returning an empty index if it hasn't
+// been synced yet (i.e. `pho hub update` has never run).
+func Load() (*Index, error) {
+	path, err := IndexPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Index{Items: map[string]HubItem{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, err
+	}
+	if index.Items == nil {
+		index.Items = map[string]HubItem{}
+	}
+	return &index, nil
+}
+
+// Save writes the index to IndexPath, creating parent directories as
+// needed.
+func (idx *Index) Save() error {
+	path, err := IndexPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Get looks up a hub item by id.
+func (idx *Index) Get(id string) (HubItem, bool) {
+	item, ok := idx.Items[id]
+	return item, ok
+}
+
+// Search returns every item whose id, name or description contains query,
+// case-insensitively. Disabled items are left out.
+func (idx *Index) Search(query string) []HubItem {
+	query = strings.ToLower(query)
+	matches := make([]HubItem, 0)
+	for _, item := range idx.Items {
+		if item.Disabled {
+			continue
+		}
+		haystack := strings.ToLower(item.Id + " " + item.Name + " " + item.Description)
+		if strings.Contains(haystack, query) {
+			matches = append(matches, item)
+		}
+	}
+	return matches
+}
+
+// Update fetches the index.json published at each hub URL (cloning it
+// with git if the URL looks like a git remote, otherwise a plain HTTP GET)
+// and merges them into the local index, later URLs winning on id
+// conflicts. The merged index is persisted before it is returned.
+func Update(urls []string) (*Index, error) {
+	merged := &Index{Items: map[string]HubItem{}}
+	for _, url := range urls {
+		data, err := fetchIndex(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync hub %s: %w", url, err)
+		}
+		var index Index
+		if err := json.Unmarshal(data, &index); err != nil {
+			return nil, fmt.Errorf("invalid index from hub %s: %w", url, err)
+		}
+		for id, item := range index.Items {
+			merged.Items[id] = item
+		}
+	}
+	if err := merged.Save(); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func fetchIndex(url string) ([]byte, error) {
+	if isGitUrl(url) {
+		return fetchIndexFromGit(url)
+	}
+	return fetchIndexOverHttp(url)
+}
+
+func isGitUrl(url string) bool {
+	return strings.HasSuffix(url, ".git") || strings.HasPrefix(url, "git@")
+}
+
+func fetchIndexOverHttp(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func fetchIndexFromGit(url string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "pho-hub-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	cmd := exec.Command("git", "clone", "--depth", "1", url, dir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git clone failed: %w: %s", err, output)
+	}
+	return os.ReadFile(filepath.Join(dir, "index.json"))
+}
@@ -0,0 +1,77 @@
+package hub
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/zyrouge/pho/core"
+)
+
+// DownloadLatest resolves the item's source and returns the release asset
+// that would be installed, without downloading or installing anything.
+// Callers that want the full install pipeline (verification, progress,
+// desktop integration) should go through `pho install <id>` instead, which
+// builds on the same core.GithubSource this returns.
+func (x *HubItem) DownloadLatest() (*core.GithubApiReleaseAsset, error) {
+	source, err := x.ToGithubSource()
+	if err != nil {
+		return nil, err
+	}
+	release, err := source.FetchAptRelease()
+	if err != nil {
+		return nil, err
+	}
+	_, asset := core.ChooseAptAppImageAsset(
+		release.Assets,
+		func(x *core.GithubApiReleaseAsset) string {
+			return x.Name
+		},
+	)
+	if asset == nil {
+		return nil, fmt.Errorf("no valid asset in release %s", release.TagName)
+	}
+	return asset, nil
+}
+
+// LatestVersion resolves the item's source and returns just the release
+// tag that would be installed, so callers can decide whether an upgrade is
+// needed before running the full install pipeline.
+func (x *HubItem) LatestVersion() (*core.GithubSource, string, error) {
+	source, err := x.ToGithubSource()
+	if err != nil {
+		return nil, "", err
+	}
+	release, err := source.FetchAptRelease()
+	if err != nil {
+		return nil, "", err
+	}
+	return source, release.TagName, nil
+}
+
+// Remove deletes the installed app's directory and drops it from config.
+func (x *HubItem) Remove(config *core.Config) error {
+	dir, ok := config.Installed[x.Id]
+	if !ok {
+		return fmt.Errorf("application %q is not installed", x.Id)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	delete(config.Installed, x.Id)
+	return core.SaveConfig(config)
+}
+
+// Enable clears the item's Disabled flag and persists the index.
+func (x *HubItem) Enable(index *Index) error {
+	x.Disabled = false
+	index.Items[x.Id] = *x
+	return index.Save()
+}
+
+// Disable marks the item as Disabled so it is hidden from search/install
+// without removing it from the index.
+func (x *HubItem) Disable(index *Index) error {
+	x.Disabled = true
+	index.Items[x.Id] = *x
+	return index.Save()
+}
@@ -0,0 +1,81 @@
+package core
+
+import "os"
+
+// RollbackInstallation undoes an interrupted install/upgrade: it deletes
+// any half-written dirs/files the journal recorded, restores the previous
+// AppImage from its backup if this operation had already overwritten it,
+// then restores the previous AppConfig and config.Installed entry if this
+// was an upgrade, or removes the (never-completed) entry if it was a fresh
+// install.
+//
+// InvolvedDirs/InvolvedFiles must only ever list paths exclusively owned by
+// this operation; on an upgrade that excludes the app's dir and desktop
+// entry, since those still hold the previous, working install.
+func RollbackInstallation(appId string, pending PendingInstallation, config *Config) error {
+	for _, file := range pending.InvolvedFiles {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	for _, dir := range pending.InvolvedDirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+
+	if pending.PreviousApp != nil {
+		appPaths := GetAppPaths(config, pending.PreviousApp.Id, pending.PreviousApp.Name)
+		if pending.BackupAppImage != "" {
+			// The new AppImage was already swapped into place before this
+			// operation failed; put the previous one back so the restored
+			// AppConfig below actually matches what's on disk.
+			if err := os.Rename(pending.BackupAppImage, appPaths.AppImage); err != nil {
+				return err
+			}
+		}
+		if err := SaveAppConfig(appPaths.Config, pending.PreviousApp); err != nil {
+			return err
+		}
+		config.Installed[appId] = appPaths.Dir
+	} else {
+		delete(config.Installed, appId)
+	}
+	return SaveConfig(config)
+}
+
+// FinishInstallation completes an install/upgrade that was interrupted
+// only after its AppImage and desktop file were already in place (phase
+// PhaseSavingConfig), by re-reading the AppConfig it had already written
+// and making sure config.Installed points at it.
+func FinishInstallation(appId string, pending PendingInstallation, config *Config) error {
+	dir := ""
+	if len(pending.InvolvedDirs) > 0 {
+		dir = pending.InvolvedDirs[0]
+	}
+	appName := appId
+	if pending.PreviousApp != nil {
+		appName = pending.PreviousApp.Name
+	}
+	appPaths := GetAppPaths(config, appId, appName)
+	if dir == "" {
+		dir = appPaths.Dir
+	}
+	if _, err := ReadAppConfig(config, appId); err != nil {
+		return err
+	}
+	config.Installed[appId] = dir
+	if pending.BackupAppImage != "" {
+		// The new AppImage is already in place and accounted for; the
+		// backup of the previous one is no longer needed.
+		os.Remove(pending.BackupAppImage)
+	}
+	return SaveConfig(config)
+}
+
+// CanFinish reports whether an interrupted operation reached the point
+// where its AppImage and desktop file are already in place, and so can be
+// finished instead of rolled back.
+func (p *PendingInstallation) CanFinish() bool {
+	return p.Phase == PhaseSavingConfig
+}
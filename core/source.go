@@ -0,0 +1,46 @@
+package core
+
+// Source is implemented by every upstream an application can be installed
+// from. It captures the three things the install pipeline needs regardless
+// of provider: recognising one of its URLs, deriving a default app id for
+// it, and fetching the release to install.
+//
+// GithubSource predates this interface and keeps its own release/asset
+// types for now; it is migrated separately. New sources implement Source
+// directly and go through installGenericSource instead.
+type Source interface {
+	FetchAptRelease() (*SourceRelease, error)
+	ParseRepoUrl(url string) (ok bool, owner string, repo string)
+	ConstructAppId(owner string, repo string) string
+}
+
+// SourceId identifies which Source implementation an installed app came
+// from, so its source config can be decoded back into the right type.
+type SourceId string
+
+const (
+	GitlabSourceId      SourceId = "gitlab"
+	GiteaSourceId       SourceId = "gitea"
+	SourceforgeSourceId SourceId = "sourceforge"
+)
+
+// SourceRelease is a provider-agnostic release: a tag plus its assets.
+type SourceRelease struct {
+	TagName string
+	Assets  []SourceAsset
+}
+
+// SourceAsset is a provider-agnostic release asset.
+type SourceAsset struct {
+	Name        string
+	DownloadUrl string
+	Size        int64
+}
+
+func (x *SourceAsset) ToAsset() *Asset {
+	return &Asset{
+		Name:        x.Name,
+		DownloadUrl: x.DownloadUrl,
+		Size:        x.Size,
+	}
+}
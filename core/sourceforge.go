@@ -0,0 +1,80 @@
+package core
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var sourceforgeRepoUrlPattern = regexp.MustCompile(`^https?://sourceforge\.net/projects/([\w.\-]+)/?`)
+
+// SourceforgeSource installs from a SourceForge project's file releases.
+// SourceForge projects are not owner-scoped, so there is no owner field:
+// ParseRepoUrl returns an empty owner and ConstructAppId ignores it.
+type SourceforgeSource struct {
+	ProjectName string
+	TagName     string
+}
+
+type sourceforgeRssItem struct {
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type sourceforgeRssFeed struct {
+	Items []sourceforgeRssItem `xml:"channel>item"`
+}
+
+// FetchAptRelease lists the project's files via its RSS feed (SourceForge
+// has no stable JSON releases API) and treats the most recent directory
+// entry as the release "tag".
+func (x *SourceforgeSource) FetchAptRelease() (*SourceRelease, error) {
+	feedUrl := fmt.Sprintf("https://sourceforge.net/projects/%s/rss?path=/%s", x.ProjectName, x.TagName)
+	resp, err := http.Get(feedUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("sourceforge rss request failed: status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var feed sourceforgeRssFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, err
+	}
+	if len(feed.Items) == 0 {
+		return nil, fmt.Errorf("no files found for sourceforge project %s", x.ProjectName)
+	}
+
+	tagName := x.TagName
+	assets := make([]SourceAsset, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		filePath := strings.TrimSuffix(item.Link, "/download")
+		name := path.Base(filePath)
+		assets = append(assets, SourceAsset{Name: name, DownloadUrl: item.Link})
+		if tagName == "" {
+			tagName = path.Dir(strings.TrimPrefix(filePath, fmt.Sprintf("https://sourceforge.net/projects/%s/files/", x.ProjectName)))
+		}
+	}
+	return &SourceRelease{TagName: tagName, Assets: assets}, nil
+}
+
+func (x *SourceforgeSource) ParseRepoUrl(repoUrl string) (bool, string, string) {
+	match := sourceforgeRepoUrlPattern.FindStringSubmatch(repoUrl)
+	if match == nil {
+		return false, "", ""
+	}
+	return true, "", match[1]
+}
+
+func (x *SourceforgeSource) ConstructAppId(_ string, repo string) string {
+	return fmt.Sprintf("%s-sourceforge", repo)
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TransactionPhase marks how far an in-flight install/upgrade had gotten
+// before it was interrupted, so `pho recover` knows what is safe to finish
+// versus what must be rolled back.
+type TransactionPhase string
+
+const (
+	PhaseDownloading  TransactionPhase = "downloading"
+	PhaseIntegrating  TransactionPhase = "integrating"
+	PhaseSavingConfig TransactionPhase = "saving-config"
+)
+
+// PendingInstallation is a single journaled operation: everything needed
+// to undo it (or, for the final phase, finish it) if `pho` is interrupted
+// before it completes.
+type PendingInstallation struct {
+	InvolvedDirs  []string         `json:"involvedDirs"`
+	InvolvedFiles []string         `json:"involvedFiles"`
+	Phase         TransactionPhase `json:"phase"`
+	// PreviousApp is a snapshot of the app's prior AppConfig, set when this
+	// operation is an upgrade rather than a fresh install, so rollback can
+	// restore it.
+	PreviousApp *AppConfig `json:"previousApp,omitempty"`
+	// BackupAppImage is where the previous AppImage was moved aside to
+	// before the new one was written in its place, set only when this
+	// operation overwrote an existing install. Rollback restores it;
+	// finishing the install discards it.
+	BackupAppImage string `json:"backupAppImage,omitempty"`
+}
+
+// Transactions is the on-disk journal of every in-flight install/upgrade.
+// Entries are removed as operations complete; anything left over after a
+// crash is what `pho recover` acts on.
+type Transactions struct {
+	PendingInstallations map[string]PendingInstallation `json:"pendingInstallations"`
+}
+
+// TransactionsPath returns ~/.config/pho/transactions.json.
+func TransactionsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pho", "transactions.json"), nil
+}
+
+// ReadTransactions reads the journal, returning an empty one if it doesn't
+// exist yet.
+func ReadTransactions() (*Transactions, error) {
+	path, err := TransactionsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Transactions{PendingInstallations: map[string]PendingInstallation{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var transactions Transactions
+	if err := json.Unmarshal(data, &transactions); err != nil {
+		return nil, err
+	}
+	if transactions.PendingInstallations == nil {
+		transactions.PendingInstallations = map[string]PendingInstallation{}
+	}
+	return &transactions, nil
+}
+
+// SaveTransactions persists the journal, creating parent directories as
+// needed.
+func SaveTransactions(transactions *Transactions) error {
+	path, err := TransactionsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(transactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// transactionsMu serializes every read-modify-write of the journal, since
+// InstallApps's worker pool calls UpdateTransactions from several
+// goroutines at once; without it, two workers reading before either writes
+// back would each save a view missing the other's entry.
+var transactionsMu sync.Mutex
+
+// UpdateTransactions reads the journal, applies fn to it, and persists the
+// result. It is the only way callers should mutate the journal, so reads
+// and writes stay paired.
+func UpdateTransactions(fn func(transactions *Transactions) error) error {
+	transactionsMu.Lock()
+	defer transactionsMu.Unlock()
+
+	transactions, err := ReadTransactions()
+	if err != nil {
+		return err
+	}
+	if err := fn(transactions); err != nil {
+		return err
+	}
+	return SaveTransactions(transactions)
+}